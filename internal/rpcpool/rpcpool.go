@@ -0,0 +1,443 @@
+// Package rpcpool provides a multi-provider Ethereum JSON-RPC client that
+// fails over between an ordered list of endpoints when one of them is slow,
+// unhealthy, or returns an error.
+package rpcpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	healthCheckInterval = 30 * time.Second
+	healthCheckTimeout  = 5 * time.Second
+	initialBackoff      = 2 * time.Second
+	maxBackoff          = 2 * time.Minute
+
+	// callTimeout bounds a single provider attempt, independent of any
+	// deadline on the caller's context, so a provider that accepts a
+	// connection but never responds trips failover instead of hanging
+	// the request forever.
+	callTimeout = 8 * time.Second
+)
+
+// ProviderStatus is a point-in-time snapshot of a single endpoint's health.
+type ProviderStatus struct {
+	URL       string    `json:"url"`
+	Healthy   bool      `json:"healthy"`
+	LastError string    `json:"lastError,omitempty"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+type provider struct {
+	url    string
+	client *ethclient.Client
+
+	mu           sync.Mutex
+	healthy      bool
+	consecFails  int
+	backoffUntil time.Time
+	lastErr      error
+	checkedAt    time.Time
+}
+
+// Client is an ordered pool of Ethereum RPC endpoints that is API-compatible
+// with the subset of *ethclient.Client methods the HTTP handlers use. Calls
+// are attempted against providers in order, skipping any currently in
+// backoff, and fail over to the next provider on error.
+type Client struct {
+	providers []*provider
+	stop      chan struct{}
+}
+
+// New dials every endpoint in urls, health-checks them, and starts a
+// background goroutine that re-checks them on healthCheckInterval. urls are
+// tried in the given order on every call, so put the most trusted/fastest
+// endpoint first.
+func New(urls []string) (*Client, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("rpcpool: at least one endpoint is required")
+	}
+
+	providers := make([]*provider, 0, len(urls))
+	for _, url := range urls {
+		c, err := ethclient.Dial(url)
+		if err != nil {
+			return nil, fmt.Errorf("rpcpool: dial %s: %w", url, err)
+		}
+		providers = append(providers, &provider{url: url, client: c})
+	}
+
+	pool := &Client{providers: providers, stop: make(chan struct{})}
+	for _, p := range pool.providers {
+		p.checkHealth()
+	}
+	go pool.healthLoop()
+
+	return pool, nil
+}
+
+// Close shuts down the background health checker and every underlying
+// ethclient connection.
+func (c *Client) Close() {
+	close(c.stop)
+	for _, p := range c.providers {
+		p.client.Close()
+	}
+}
+
+// Status returns a snapshot of every provider's health, in endpoint order.
+func (c *Client) Status() []ProviderStatus {
+	statuses := make([]ProviderStatus, len(c.providers))
+	for i, p := range c.providers {
+		p.mu.Lock()
+		statuses[i] = ProviderStatus{
+			URL:       p.url,
+			Healthy:   p.healthy,
+			CheckedAt: p.checkedAt,
+		}
+		if p.lastErr != nil {
+			statuses[i].LastError = p.lastErr.Error()
+		}
+		p.mu.Unlock()
+	}
+	return statuses
+}
+
+func (c *Client) healthLoop() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			for _, p := range c.providers {
+				p.checkHealth()
+			}
+		}
+	}
+}
+
+func (p *provider) checkHealth() {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	var version string
+	err := p.client.Client().CallContext(ctx, &version, "web3_clientVersion")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.checkedAt = time.Now()
+	p.lastErr = err
+	p.healthy = err == nil
+}
+
+// inBackoff reports whether the provider is currently being skipped after
+// consecutive failures, and must be called with p.mu held.
+func (p *provider) inBackoff() bool {
+	return time.Now().Before(p.backoffUntil)
+}
+
+func (p *provider) recordSuccess() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.consecFails = 0
+	p.backoffUntil = time.Time{}
+	p.healthy = true
+}
+
+func (p *provider) recordFailure(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.consecFails++
+	p.lastErr = err
+	p.healthy = false
+
+	backoff := initialBackoff << uint(p.consecFails-1)
+	if backoff > maxBackoff || backoff <= 0 {
+		backoff = maxBackoff
+	}
+	p.backoffUntil = time.Now().Add(backoff)
+}
+
+// call runs fn against each provider in order, skipping ones in backoff,
+// until one succeeds or all have been tried. Each attempt gets its own
+// bounded context derived from ctx, independent of whether ctx itself
+// carries a deadline, so a provider that never responds fails over
+// instead of hanging the request.
+func (c *Client) call(ctx context.Context, fn func(context.Context, *ethclient.Client) error) error {
+	var lastErr error
+	tried := false
+
+	for _, p := range c.providers {
+		p.mu.Lock()
+		skip := p.inBackoff()
+		p.mu.Unlock()
+		if skip {
+			continue
+		}
+
+		tried = true
+		attemptCtx, cancel := context.WithTimeout(ctx, callTimeout)
+		err := fn(attemptCtx, p.client)
+		cancel()
+		if err == nil {
+			p.recordSuccess()
+			return nil
+		}
+		p.recordFailure(err)
+		lastErr = err
+	}
+
+	if !tried {
+		return errors.New("rpcpool: all providers are in backoff")
+	}
+	return fmt.Errorf("rpcpool: all providers failed, last error: %w", lastErr)
+}
+
+// BalanceAt mirrors ethclient.Client.BalanceAt, failing over across providers.
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result *big.Int
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.BalanceAt(attemptCtx, account, blockNumber)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// PendingBalanceAt mirrors ethclient.Client.PendingBalanceAt.
+func (c *Client) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	var result *big.Int
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.PendingBalanceAt(attemptCtx, account)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// TransactionByHash mirrors ethclient.Client.TransactionByHash.
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	var (
+		tx      *types.Transaction
+		pending bool
+	)
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		t, p, err := ec.TransactionByHash(attemptCtx, hash)
+		if err == nil {
+			tx, pending = t, p
+		}
+		return err
+	})
+	return tx, pending, err
+}
+
+// BlockByNumber mirrors ethclient.Client.BlockByNumber.
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	var result *types.Block
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.BlockByNumber(attemptCtx, number)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// CallContract mirrors ethclient.Client.CallContract.
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.CallContract(attemptCtx, msg, blockNumber)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// EstimateGas mirrors ethclient.Client.EstimateGas.
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var result uint64
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.EstimateGas(attemptCtx, msg)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// The methods below round out bind.ContractBackend so a *Client can be
+// passed anywhere an *ethclient.Client is used as a go-ens/abigen backend.
+
+// CodeAt mirrors ethclient.Client.CodeAt.
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var result []byte
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.CodeAt(attemptCtx, account, blockNumber)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// PendingCodeAt mirrors ethclient.Client.PendingCodeAt.
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var result []byte
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.PendingCodeAt(attemptCtx, account)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// PendingCallContract mirrors ethclient.Client.PendingCallContract.
+func (c *Client) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	var result []byte
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.PendingCallContract(attemptCtx, msg)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// PendingNonceAt mirrors ethclient.Client.PendingNonceAt.
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result uint64
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.PendingNonceAt(attemptCtx, account)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// HeaderByNumber mirrors ethclient.Client.HeaderByNumber.
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	var result *types.Header
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.HeaderByNumber(attemptCtx, number)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// SuggestGasPrice mirrors ethclient.Client.SuggestGasPrice.
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.SuggestGasPrice(attemptCtx)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// SuggestGasTipCap mirrors ethclient.Client.SuggestGasTipCap.
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.SuggestGasTipCap(attemptCtx)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// SendTransaction mirrors ethclient.Client.SendTransaction. It is not
+// failed over: a transaction must not be broadcast to more than one
+// provider, so it is only ever sent to the first provider not in backoff.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	for _, p := range c.providers {
+		p.mu.Lock()
+		skip := p.inBackoff()
+		p.mu.Unlock()
+		if skip {
+			continue
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, callTimeout)
+		err := p.client.SendTransaction(attemptCtx, tx)
+		cancel()
+		if err == nil {
+			p.recordSuccess()
+		} else {
+			p.recordFailure(err)
+		}
+		return err
+	}
+	return errors.New("rpcpool: all providers are in backoff")
+}
+
+// FilterLogs mirrors ethclient.Client.FilterLogs.
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var result []types.Log
+	err := c.call(ctx, func(attemptCtx context.Context, ec *ethclient.Client) error {
+		v, err := ec.FilterLogs(attemptCtx, q)
+		if err == nil {
+			result = v
+		}
+		return err
+	})
+	return result, err
+}
+
+// SubscribeFilterLogs mirrors ethclient.Client.SubscribeFilterLogs. Like
+// SendTransaction, a subscription is stateful and is only ever opened
+// against the first provider not in backoff rather than retried across the
+// pool.
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	for _, p := range c.providers {
+		p.mu.Lock()
+		skip := p.inBackoff()
+		p.mu.Unlock()
+		if skip {
+			continue
+		}
+		attemptCtx, cancel := context.WithTimeout(ctx, callTimeout)
+		sub, err := p.client.SubscribeFilterLogs(attemptCtx, q, ch)
+		cancel()
+		if err == nil {
+			p.recordSuccess()
+			return sub, nil
+		}
+		p.recordFailure(err)
+		return nil, err
+	}
+	return nil, errors.New("rpcpool: all providers are in backoff")
+}
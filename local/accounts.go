@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// weiToEther converts a wei amount to an exact ether-denominated decimal
+// string. big.Rat is used instead of big.Float because Float division only
+// carries as much precision as the inputs, which rounds the last digit on
+// large balances.
+func weiToEther(wei *big.Int) string {
+	return new(big.Rat).SetFrac(wei, big.NewInt(1e18)).FloatString(18)
+}
+
+// isHexHash reports whether s is a 32-byte hash encoded as hex, with an
+// optional "0x" prefix.
+func isHexHash(s string) bool {
+	s = strings.TrimPrefix(s, "0x")
+	if len(s) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+type balanceResponse struct {
+	Address string `json:"address"`
+	Block   string `json:"block,omitempty"`
+	Wei     string `json:"wei"`
+	Ether   string `json:"ether"`
+}
+
+// balanceHandler serves /balance/:address and /balance/:address/:block.
+func balanceHandler(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/balance/")
+	parts := strings.SplitN(path, "/", 2)
+	address := parts[0]
+	if address == "" {
+		writeError(w, http.StatusBadRequest, "missing address")
+		return
+	}
+	if !common.IsHexAddress(address) {
+		writeError(w, http.StatusBadRequest, "invalid address")
+		return
+	}
+	account := common.HexToAddress(address)
+
+	var wei *big.Int
+	var blockParam string
+	var err error
+
+	if len(parts) == 2 && parts[1] != "" {
+		blockParam = parts[1]
+		blockNumber, ok := new(big.Int).SetString(blockParam, 10)
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid block number")
+			return
+		}
+		wei, err = client.BalanceAt(context.Background(), account, blockNumber)
+	} else {
+		wei, err = client.PendingBalanceAt(context.Background(), account)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	writeJSON(w, balanceResponse{
+		Address: address,
+		Block:   blockParam,
+		Wei:     wei.String(),
+		Ether:   weiToEther(wei),
+	})
+}
+
+type txResponse struct {
+	Hash     string `json:"hash"`
+	Pending  bool   `json:"pending"`
+	To       string `json:"to,omitempty"`
+	Value    string `json:"value"`
+	Nonce    uint64 `json:"nonce"`
+	GasLimit uint64 `json:"gasLimit"`
+}
+
+// txHandler serves /tx/:hash.
+func txHandler(w http.ResponseWriter, r *http.Request) {
+	hash := strings.TrimPrefix(r.URL.Path, "/tx/")
+	if hash == "" {
+		writeError(w, http.StatusBadRequest, "missing hash")
+		return
+	}
+	if !isHexHash(hash) {
+		writeError(w, http.StatusBadRequest, "invalid hash")
+		return
+	}
+
+	tx, pending, err := client.TransactionByHash(context.Background(), common.HexToHash(hash))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	resp := txResponse{
+		Hash:     tx.Hash().Hex(),
+		Pending:  pending,
+		Value:    tx.Value().String(),
+		Nonce:    tx.Nonce(),
+		GasLimit: tx.Gas(),
+	}
+	if to := tx.To(); to != nil {
+		resp.To = to.Hex()
+	}
+
+	writeJSON(w, resp)
+}
+
+type blockResponse struct {
+	Number       uint64   `json:"number"`
+	Hash         string   `json:"hash"`
+	Timestamp    uint64   `json:"timestamp"`
+	Transactions []string `json:"transactions"`
+}
+
+// blockHandler serves /block/:number.
+func blockHandler(w http.ResponseWriter, r *http.Request) {
+	number := strings.TrimPrefix(r.URL.Path, "/block/")
+	if number == "" {
+		writeError(w, http.StatusBadRequest, "missing block number")
+		return
+	}
+
+	blockNumber, ok := new(big.Int).SetString(number, 10)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "invalid block number")
+		return
+	}
+
+	block, err := client.BlockByNumber(context.Background(), blockNumber)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	hashes := make([]string, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		hashes[i] = tx.Hash().Hex()
+	}
+
+	writeJSON(w, blockResponse{
+		Number:       block.NumberU64(),
+		Hash:         block.Hash().Hex(),
+		Timestamp:    block.Time(),
+		Transactions: hashes,
+	})
+}
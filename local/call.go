@@ -0,0 +1,300 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// abiCache holds parsed ABIs keyed by the sha256 of their raw JSON, so
+// repeated /call and /estimateGas requests using the same ABI skip
+// re-parsing it every time.
+var (
+	abiCacheMu sync.Mutex
+	abiCache   = map[string]abi.ABI{}
+)
+
+func parseABICached(raw json.RawMessage) (abi.ABI, error) {
+	sum := sha256.Sum256(raw)
+	key := hex.EncodeToString(sum[:])
+
+	abiCacheMu.Lock()
+	defer abiCacheMu.Unlock()
+
+	if parsed, ok := abiCache[key]; ok {
+		return parsed, nil
+	}
+
+	parsed, err := abi.JSON(strings.NewReader(string(raw)))
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	abiCache[key] = parsed
+	return parsed, nil
+}
+
+type callRequest struct {
+	To     string          `json:"to"`
+	ABI    json.RawMessage `json:"abi"`
+	Method string          `json:"method"`
+	Args   []interface{}   `json:"args"`
+	Block  string          `json:"block"`
+}
+
+// decodedCall is the result of parsing and packing a /call or
+// /estimateGas request body.
+type decodedCall struct {
+	method      abi.Method
+	contract    common.Address
+	data        []byte
+	blockNumber *big.Int
+}
+
+// decodeCallRequest reads and validates the common body shared by /call and
+// /estimateGas: it resolves the method against the (cached) ABI, converts
+// and packs the arguments, and parses the target block.
+func decodeCallRequest(r *http.Request) (decodedCall, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return decodedCall{}, err
+	}
+
+	var req callRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return decodedCall{}, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	if !common.IsHexAddress(req.To) {
+		return decodedCall{}, fmt.Errorf("invalid \"to\" address")
+	}
+	contract := common.HexToAddress(req.To)
+
+	parsed, err := parseABICached(req.ABI)
+	if err != nil {
+		return decodedCall{}, fmt.Errorf("invalid abi: %w", err)
+	}
+
+	method, ok := parsed.Methods[req.Method]
+	if !ok {
+		return decodedCall{}, fmt.Errorf("method %q not found in abi", req.Method)
+	}
+
+	args, err := convertArgs(method.Inputs, req.Args)
+	if err != nil {
+		return decodedCall{}, fmt.Errorf("argument conversion: %w", err)
+	}
+
+	data, err := parsed.Pack(req.Method, args...)
+	if err != nil {
+		return decodedCall{}, fmt.Errorf("packing call data: %w", err)
+	}
+
+	var blockNumber *big.Int
+	if req.Block != "" && req.Block != "latest" {
+		n, ok := new(big.Int).SetString(req.Block, 10)
+		if !ok {
+			return decodedCall{}, fmt.Errorf("invalid block number")
+		}
+		blockNumber = n
+	}
+
+	return decodedCall{method: method, contract: contract, data: data, blockNumber: blockNumber}, nil
+}
+
+// convertArgs coerces the JSON-decoded argument values into the Go types
+// go-ethereum's abi package expects for packing, based on each input's
+// declared Solidity type.
+func convertArgs(inputs abi.Arguments, raw []interface{}) ([]interface{}, error) {
+	if len(raw) != len(inputs) {
+		return nil, fmt.Errorf("expected %d args, got %d", len(inputs), len(raw))
+	}
+
+	args := make([]interface{}, len(raw))
+	for i, input := range inputs {
+		converted, err := convertArg(input.Type, raw[i])
+		if err != nil {
+			return nil, fmt.Errorf("arg %d (%s): %w", i, input.Name, err)
+		}
+		args[i] = converted
+	}
+	return args, nil
+}
+
+func convertArg(t abi.Type, v interface{}) (interface{}, error) {
+	switch t.T {
+	case abi.AddressTy:
+		s, ok := v.(string)
+		if !ok || !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("expected hex address string")
+		}
+		return common.HexToAddress(s), nil
+
+	case abi.IntTy, abi.UintTy:
+		var i *big.Int
+		switch n := v.(type) {
+		case string:
+			var ok bool
+			if i, ok = new(big.Int).SetString(n, 10); !ok {
+				return nil, fmt.Errorf("expected decimal integer string")
+			}
+		case float64:
+			i = big.NewInt(int64(n))
+		default:
+			return nil, fmt.Errorf("expected number or decimal string")
+		}
+		// The abi package takes *big.Int only for sizes without a native Go
+		// type; int8..int64 and uint8..uint64 must be passed natively.
+		if t.GetType() == reflect.TypeOf(i) {
+			return i, nil
+		}
+		if t.T == abi.UintTy {
+			return reflect.ValueOf(i.Uint64()).Convert(t.GetType()).Interface(), nil
+		}
+		return reflect.ValueOf(i.Int64()).Convert(t.GetType()).Interface(), nil
+
+	case abi.BoolTy:
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool")
+		}
+		return b, nil
+
+	case abi.StringTy:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string")
+		}
+		return s, nil
+
+	case abi.BytesTy, abi.FixedBytesTy:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected hex string")
+		}
+		b, err := hexutilDecode(s)
+		if err != nil || t.T == abi.BytesTy {
+			return b, err
+		}
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", t.Size, len(b))
+		}
+		arr := reflect.New(t.GetType()).Elem()
+		reflect.Copy(arr, reflect.ValueOf(b))
+		return arr.Interface(), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported abi type %s", t.String())
+	}
+}
+
+func hexutilDecode(s string) ([]byte, error) {
+	s = strings.TrimPrefix(s, "0x")
+	return hex.DecodeString(s)
+}
+
+// callHandler serves POST /call: it packs a method call against an
+// arbitrary ABI, runs it as an eth_call, and decodes the return values.
+func callHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "POST required")
+		return
+	}
+
+	call, err := decodeCallRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	msg := ethereum.CallMsg{To: &call.contract, Data: call.data}
+	result, err := client.CallContract(context.Background(), msg, call.blockNumber)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	values, err := call.method.Outputs.UnpackValues(result)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Sprintf("decoding return values: %s", err))
+		return
+	}
+
+	jsonValues := make([]interface{}, len(values))
+	for i, v := range values {
+		jsonValues[i] = jsonSafe(v)
+	}
+
+	writeJSON(w, map[string]interface{}{"result": jsonValues})
+}
+
+// jsonSafe recursively converts *big.Int values (the Go representation of
+// Solidity's uintN/intN types) to decimal strings, since encoding/json
+// marshals big.Int as a bare number and silently loses precision above
+// 2^53 for any JS/JSON consumer. Byte slices/arrays (bytesN, address) are
+// left untouched since they already marshal safely.
+func jsonSafe(v interface{}) interface{} {
+	if i, ok := v.(*big.Int); ok {
+		return i.String()
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		out := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out[i] = jsonSafe(rv.Index(i).Interface())
+		}
+		return out
+	case reflect.Struct:
+		out := make(map[string]interface{}, rv.NumField())
+		t := rv.Type()
+		for i := 0; i < rv.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			out[t.Field(i).Name] = jsonSafe(rv.Field(i).Interface())
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// estimateGasHandler serves POST /estimateGas using the same request body
+// shape as /call.
+func estimateGasHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "POST required")
+		return
+	}
+
+	call, err := decodeCallRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	msg := ethereum.CallMsg{To: &call.contract, Data: call.data}
+	gas, err := client.EstimateGas(context.Background(), msg)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{"gas": gas})
+}
@@ -1,19 +1,159 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	ens "github.com/wealdtech/go-ens/v3"
+
+	"github.com/hopelessRomant/Lobby/internal/rpcpool"
 )
 
+var client *rpcpool.Client
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: message})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
 func handler(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "Hello, Go API is live!")
 }
 
+type ensResolveResponse struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	ContentHash string `json:"contentHash,omitempty"`
+}
+
+// ensResolveHandler resolves an ENS name to an address and, if available, its content hash.
+func ensResolveHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/ens/resolve/")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "missing name")
+		return
+	}
+
+	address, err := ens.Resolve(client, name)
+	if err != nil {
+		if strings.Contains(err.Error(), "no resolver") {
+			writeError(w, http.StatusNotFound, "no resolver set")
+			return
+		}
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	resp := ensResolveResponse{Name: name, Address: address.Hex()}
+
+	if resolver, err := ens.NewResolver(client, name); err == nil {
+		if hash, err := resolver.Contenthash(); err == nil {
+			resp.ContentHash = fmt.Sprintf("0x%x", hash)
+		}
+	}
+
+	writeJSON(w, resp)
+}
+
+type ensReverseResponse struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+}
+
+// ensReverseHandler looks up the primary ENS name registered for an address.
+func ensReverseHandler(w http.ResponseWriter, r *http.Request) {
+	addrParam := strings.TrimPrefix(r.URL.Path, "/ens/reverse/")
+	if addrParam == "" {
+		writeError(w, http.StatusBadRequest, "missing address")
+		return
+	}
+	if !common.IsHexAddress(addrParam) {
+		writeError(w, http.StatusBadRequest, "invalid address")
+		return
+	}
+
+	name, err := ens.ReverseResolve(client, common.HexToAddress(addrParam))
+	if err != nil {
+		if strings.Contains(err.Error(), "no resolution") || strings.Contains(err.Error(), "not a resolver") {
+			writeError(w, http.StatusNotFound, "no reverse record")
+			return
+		}
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	writeJSON(w, ensReverseResponse{Address: addrParam, Name: name})
+}
+
+func rpcURLs() []string {
+	raw := os.Getenv("ETH_RPC_URLS")
+	if raw == "" {
+		log.Fatal("ETH_RPC_URLS environment variable not set")
+	}
+
+	var urls []string
+	for _, url := range strings.Split(raw, ",") {
+		url = strings.TrimSpace(url)
+		if url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, client.Status())
+}
+
 func main() {
+	var err error
+	client, err = rpcpool.New(rpcURLs())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	fmt.Println("Connected to Ethereum RPC pool!")
+
+	wsURL := os.Getenv("ETH_WS_URL")
+	if wsURL == "" {
+		log.Fatal("ETH_WS_URL environment variable not set")
+	}
+	wsClient, err = ethclient.Dial(wsURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer wsClient.Close()
+
 	http.HandleFunc("/", handler)
+	http.HandleFunc("/health", healthHandler)
+	http.HandleFunc("/ens/resolve/", ensResolveHandler)
+	http.HandleFunc("/ens/reverse/", ensReverseHandler)
+	http.HandleFunc("/balance/", balanceHandler)
+	http.HandleFunc("/tx/", txHandler)
+	http.HandleFunc("/block/", blockHandler)
+	http.HandleFunc("/ws/blocks", blocksWSHandler)
+	http.HandleFunc("/ws/pending", pendingWSHandler)
+	http.HandleFunc("/call", callHandler)
+	http.HandleFunc("/estimateGas", estimateGasHandler)
+
 	fmt.Println("🚀 Server running on http://localhost:8080")
-	err := http.ListenAndServe(":8080", nil)
+	err = http.ListenAndServe(":8080", nil)
 	if err != nil {
 		log.Fatal(err)
 	}
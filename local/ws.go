@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/gorilla/websocket"
+)
+
+// wsClient is a dedicated websocket connection used for subscriptions.
+// Subscriptions are stateful and tied to a single connection, so they are
+// not routed through the failover pool in client.
+var wsClient *ethclient.Client
+
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPingPeriod = 30 * time.Second
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// pingLoop writes periodic pings so idle connections aren't dropped by
+// intermediaries, and closes done when the connection can no longer be
+// written to.
+func pingLoop(conn *websocket.Conn, done chan struct{}) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				close(done)
+				return
+			}
+		}
+	}
+}
+
+// blocksWSHandler streams new block headers over /ws/blocks. The optional
+// ?fromAddress= filter is not meaningful for headers and is accepted only
+// for symmetry with /ws/pending.
+func blocksWSHandler(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("ws upgrade:", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	headers := make(chan *types.Header)
+	sub, err := wsClient.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		conn.WriteJSON(errorResponse{Error: err.Error()})
+		return
+	}
+	defer sub.Unsubscribe()
+
+	done := make(chan struct{})
+	go pingLoop(conn, done)
+
+	for {
+		select {
+		case err := <-sub.Err():
+			conn.WriteJSON(errorResponse{Error: err.Error()})
+			return
+		case header := <-headers:
+			if err := conn.WriteJSON(header); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+type pendingTxEvent struct {
+	Hash string `json:"hash"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+}
+
+// pendingWSHandler streams pending transaction hashes over /ws/pending,
+// optionally filtered server-side to a single sender via ?fromAddress=.
+func pendingWSHandler(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Println("ws upgrade:", err)
+		return
+	}
+	defer conn.Close()
+
+	var fromFilter common.Address
+	hasFilter := false
+	if addr := r.URL.Query().Get("fromAddress"); addr != "" {
+		if !common.IsHexAddress(addr) {
+			conn.WriteJSON(errorResponse{Error: "invalid fromAddress"})
+			return
+		}
+		fromFilter = common.HexToAddress(addr)
+		hasFilter = true
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	hashes := make(chan common.Hash)
+	sub, err := wsClient.Client().EthSubscribe(ctx, hashes, "newPendingTransactions")
+	if err != nil {
+		conn.WriteJSON(errorResponse{Error: err.Error()})
+		return
+	}
+	defer sub.Unsubscribe()
+
+	done := make(chan struct{})
+	go pingLoop(conn, done)
+
+	for {
+		select {
+		case err := <-sub.Err():
+			conn.WriteJSON(errorResponse{Error: err.Error()})
+			return
+		case hash := <-hashes:
+			tx, _, err := wsClient.TransactionByHash(ctx, hash)
+			if err != nil {
+				continue
+			}
+
+			event := pendingTxEvent{Hash: hash.Hex()}
+			signer := types.LatestSignerForChainID(tx.ChainId())
+			from, err := types.Sender(signer, tx)
+			if err != nil {
+				if hasFilter {
+					continue
+				}
+			} else {
+				event.From = from.Hex()
+				if hasFilter && from != fromFilter {
+					continue
+				}
+			}
+			if to := tx.To(); to != nil {
+				event.To = to.Hex()
+			}
+
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}